@@ -0,0 +1,79 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+
+	"k8s.io/api/networking/v1"
+)
+
+// v1IngressFrontendNamer implements IngressFrontendNamer for a single
+// Ingress using the legacy (V1) naming scheme: it delegates straight to
+// the cluster-wide Namer using the Ingress's "namespace/name" (folding in
+// its IngressClass, if any) as the load balancer key.
+type v1IngressFrontendNamer struct {
+	lbName string
+	namer  *Namer
+}
+
+// newV1IngressFrontendNamer returns a v1IngressFrontendNamer for ing.
+func newV1IngressFrontendNamer(ing *v1.Ingress, namer *Namer) IngressFrontendNamer {
+	namespace, name := ingressNamingComponents(ing)
+	key := fmt.Sprintf("%s/%s", namespace, name)
+	return newV1IngressFrontendNamerFromLBName(namer.LoadBalancer(key), namer)
+}
+
+// newV1IngressFrontendNamerFromLBName returns a v1IngressFrontendNamer for
+// an already-computed load balancer name. This is used when the caller
+// only has the LB name on hand, for example when garbage collecting
+// resources for an Ingress that no longer exists.
+func newV1IngressFrontendNamerFromLBName(lbName string, namer *Namer) IngressFrontendNamer {
+	return &v1IngressFrontendNamer{lbName: lbName, namer: namer}
+}
+
+func (ln *v1IngressFrontendNamer) LbName() string {
+	return ln.lbName
+}
+
+func (ln *v1IngressFrontendNamer) TargetProxy(protocol NamerProtocol) string {
+	return ln.namer.TargetProxy(ln.lbName, protocol)
+}
+
+func (ln *v1IngressFrontendNamer) SSLCertName(secretHash string) string {
+	return ln.namer.SSLCertName(ln.lbName, secretHash)
+}
+
+func (ln *v1IngressFrontendNamer) SSLCertNameForSecret(secretNamespace, secretName, secretHash string) string {
+	return ln.namer.SSLCertNameForSecret(ln.lbName, secretNamespace, secretName, secretHash)
+}
+
+func (ln *v1IngressFrontendNamer) IsSSLCertNameForLB(name string) (string, bool) {
+	hash, ok := ln.namer.sslCertLBHash(name)
+	if !ok || hash != sha256Hash(ln.lbName)[:lbHashLen] {
+		return "", false
+	}
+	return ln.lbName, true
+}
+
+func (ln *v1IngressFrontendNamer) ForwardingRule(protocol NamerProtocol) string {
+	return ln.namer.ForwardingRule(ln.lbName, protocol)
+}
+
+func (ln *v1IngressFrontendNamer) UrlMap() string {
+	return ln.namer.UrlMap(ln.lbName)
+}
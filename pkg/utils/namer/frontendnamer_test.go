@@ -22,14 +22,14 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
-	"k8s.io/api/networking/v1beta1"
+	"k8s.io/api/networking/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const clusterUID = "uid1"
 
-func newIngress(namespace, name string) *v1beta1.Ingress {
-	return &v1beta1.Ingress{
+func newIngress(namespace, name string) *v1.Ingress {
+	return &v1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      name,
 			Namespace: namespace,
@@ -37,6 +37,15 @@ func newIngress(namespace, name string) *v1beta1.Ingress {
 	}
 }
 
+// newIngressWithClass returns an Ingress with its IngressClassName set,
+// used to test that Ingresses sharing a namespace/name but selecting
+// different classes get disjoint GCE resource names.
+func newIngressWithClass(namespace, name, class string) *v1.Ingress {
+	ing := newIngress(namespace, name)
+	ing.Spec.IngressClassName = &class
+	return ing
+}
+
 // TestV1IngressFrontendNamer tests that v1 frontend namer created from load balancer,
 // 1. returns expected values.
 // 2. returns same values as old namer.
@@ -236,4 +245,55 @@ func TestV1IngressFrontendNamer(t *testing.T) {
 			})
 		}
 	}
-}
\ No newline at end of file
+}
+// TestV1IngressFrontendNamerWithIngressClass parallels
+// TestV1IngressFrontendNamer, exercising the IngressClassName folding
+// added when this package moved off networking/v1beta1.Ingress: Ingresses
+// that share a namespace/name but select different classes must get
+// disjoint resource names, while an Ingress with no class set must still
+// produce byte-identical names to before the migration.
+func TestV1IngressFrontendNamerWithIngressClass(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+
+	noClass := newV1IngressFrontendNamer(newIngress("namespace", "name"), oldNamer)
+	if got, want := noClass.LbName(), "namespace-name--uid1"; got != want {
+		t.Errorf("LbName() with no IngressClass = %q, want byte-identical %q", got, want)
+	}
+
+	gce := newV1IngressFrontendNamer(newIngressWithClass("namespace", "name", "gce"), oldNamer)
+	if got, want := gce.LbName(), "namespace-name-gce--uid1"; got != want {
+		t.Errorf(`LbName() with IngressClassName "gce" = %q, want %q`, got, want)
+	}
+	if got, want := gce.TargetProxy(HTTPProtocol), "k8s-tp-namespace-name-gce--uid1"; got != want {
+		t.Errorf(`TargetProxy(HTTP) with IngressClassName "gce" = %q, want %q`, got, want)
+	}
+
+	gceInternal := newV1IngressFrontendNamer(newIngressWithClass("namespace", "name", "gce-internal"), oldNamer)
+	if got, want := gceInternal.LbName(), "namespace-name-gce-internal--uid1"; got != want {
+		t.Errorf(`LbName() with IngressClassName "gce-internal" = %q, want %q`, got, want)
+	}
+
+	if gce.LbName() == gceInternal.LbName() {
+		t.Errorf("Ingresses with different IngressClassName produced the same LbName: %q", gce.LbName())
+	}
+	if gce.LbName() == noClass.LbName() {
+		t.Errorf("Ingress with an IngressClassName should not share a name with one that has none")
+	}
+
+	// The legacy kubernetes.io/ingress.class annotation is honored the
+	// same way as IngressClassName for one release.
+	legacy := newIngress("namespace", "name")
+	legacy.Annotations = map[string]string{legacyIngressClassAnnotationKey: "gce"}
+	legacyNamer := newV1IngressFrontendNamer(legacy, oldNamer)
+	if got, want := legacyNamer.LbName(), gce.LbName(); got != want {
+		t.Errorf("LbName() via legacy ingress.class annotation = %q, want same as IngressClassName %q", got, want)
+	}
+
+	// IngressClassName takes precedence when both are set.
+	both := newIngressWithClass("namespace", "name", "gce")
+	both.Annotations = map[string]string{legacyIngressClassAnnotationKey: "gce-internal"}
+	bothNamer := newV1IngressFrontendNamer(both, oldNamer)
+	if got, want := bothNamer.LbName(), gce.LbName(); got != want {
+		t.Errorf("LbName() with both IngressClassName and legacy annotation set = %q, want IngressClassName to win: %q", got, want)
+	}
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+
+	"k8s.io/api/networking/v1"
+)
+
+// Version identifies which naming scheme a FrontendNamer uses.
+type Version string
+
+const (
+	// V1 is the legacy tail-truncation scheme implemented by
+	// v1IngressFrontendNamer. Long namespace/name combinations can
+	// collide (see truncate in utils.go); kept only so already-created
+	// Ingresses don't have their GCE resources renamed out from under
+	// them.
+	V1 Version = "v1"
+	// V2 folds a hash of the full namespace/name into the name instead
+	// of just chopping the tail off, so it never collides regardless of
+	// length. All new Ingresses should use it.
+	V2 Version = "v2"
+)
+
+// V1NamingAnnotationKey marks an Ingress as using the legacy V1 naming
+// scheme: FrontendNamerVersion returns V1 for an Ingress carrying it,
+// whatever the default version, so an already-existing Ingress marked with
+// it keeps its GCE resource names when new Ingresses default to V2 naming.
+// Nothing in this package sets it.
+const V1NamingAnnotationKey = "networking.gke.io/frontend-naming.v1"
+
+// FrontendNamerVersion returns the naming version that should be used for
+// ing. defaultVersion is the version newly-created Ingresses get, and is
+// expected to come from a command-line flag; ing always gets V1 if it
+// carries V1NamingAnnotationKey, regardless of defaultVersion.
+func FrontendNamerVersion(ing *v1.Ingress, defaultVersion Version) Version {
+	if ing != nil {
+		if _, ok := ing.Annotations[V1NamingAnnotationKey]; ok {
+			return V1
+		}
+	}
+	return defaultVersion
+}
+
+// NewIngressFrontendNamer returns the IngressFrontendNamer for ing, picking
+// between the V1 and V2 naming schemes via FrontendNamerVersion.
+func NewIngressFrontendNamer(ing *v1.Ingress, defaultVersion Version, namer *Namer) IngressFrontendNamer {
+	if FrontendNamerVersion(ing, defaultVersion) == V1 {
+		return newV1IngressFrontendNamer(ing, namer)
+	}
+	return newV2IngressFrontendNamer(ing, namer)
+}
+
+// v2IngressFrontendNamer implements IngressFrontendNamer using the V2
+// naming scheme. Unlike v1IngressFrontendNamer it doesn't precompute a
+// shared lbName: every resource name is sized independently around its own
+// kind infix so each one is guaranteed to fit within the name limit on its
+// own, rather than composing an already near-the-limit lbName and risking
+// going over again.
+type v2IngressFrontendNamer struct {
+	namespace string
+	name      string
+	namer     *Namer
+}
+
+func newV2IngressFrontendNamer(ing *v1.Ingress, namer *Namer) IngressFrontendNamer {
+	namespace, name := ingressNamingComponents(ing)
+	return &v2IngressFrontendNamer{namespace: namespace, name: name, namer: namer}
+}
+
+func (ln *v2IngressFrontendNamer) LbName() string {
+	return v2LbName(ln.namespace, ln.name, ln.namer.UID())
+}
+
+func (ln *v2IngressFrontendNamer) TargetProxy(protocol NamerProtocol) string {
+	return v2ResourceName(ln.namer.Prefix(), targetProxyInfix(protocol), ln.namespace, ln.name, ln.namer.UID())
+}
+
+func (ln *v2IngressFrontendNamer) SSLCertName(secretHash string) string {
+	return ln.namer.SSLCertName(ln.LbName(), secretHash)
+}
+
+func (ln *v2IngressFrontendNamer) SSLCertNameForSecret(secretNamespace, secretName, secretHash string) string {
+	return ln.namer.SSLCertNameForSecret(ln.LbName(), secretNamespace, secretName, secretHash)
+}
+
+func (ln *v2IngressFrontendNamer) IsSSLCertNameForLB(name string) (string, bool) {
+	hash, ok := ln.namer.sslCertLBHash(name)
+	if !ok || hash != sha256Hash(ln.LbName())[:lbHashLen] {
+		return "", false
+	}
+	return ln.LbName(), true
+}
+
+func (ln *v2IngressFrontendNamer) ForwardingRule(protocol NamerProtocol) string {
+	return v2ResourceName(ln.namer.Prefix(), forwardingRuleInfix(protocol), ln.namespace, ln.name, ln.namer.UID())
+}
+
+func (ln *v2IngressFrontendNamer) UrlMap() string {
+	return v2ResourceName(ln.namer.Prefix(), "um", ln.namespace, ln.name, ln.namer.UID())
+}
+
+// v2LbName returns the V2 load balancer name for (namespace, name): always
+// <= nameLenLimit characters, and collision-free regardless of length
+// since the part that gets trimmed is backstopped by a hash of the
+// untrimmed namespace/name.
+func v2LbName(namespace, name, uid string) string {
+	hash8 := sha256Hash(namespace + "/" + name)[:8]
+	// Separators: "-" between namespace and name, "-" between name and
+	// hash8, then clusterNameDelimiter before uid.
+	fixedLen := 2 + len(clusterNameDelimiter) + len(hash8) + len(uid)
+	trimmed := trimFieldsEvenly(nameLenLimit-fixedLen, namespace, name)
+	return fmt.Sprintf("%s-%s-%s%s%s", trimmed[0], trimmed[1], hash8, clusterNameDelimiter, uid)
+}
+
+// v2ResourceName returns the V2 name for a single GCE resource of the
+// given kind ("tp", "fws", "um", ...) belonging to (namespace, name).
+func v2ResourceName(prefix, kind, namespace, name, uid string) string {
+	hash8 := sha256Hash(namespace + "/" + name)[:8]
+	// Separators: "-" between prefix/kind/namespace/name/hash8 (4 of
+	// them), then clusterNameDelimiter before uid.
+	fixedLen := 4 + len(clusterNameDelimiter) + len(prefix) + len(kind) + len(hash8) + len(uid)
+	trimmed := trimFieldsEvenly(nameLenLimit-fixedLen, namespace, name)
+	return fmt.Sprintf("%s-%s-%s-%s-%s%s%s", prefix, kind, trimmed[0], trimmed[1], hash8, clusterNameDelimiter, uid)
+}
+
+// FrontendNameSet holds every well-known GCE resource name derived from a
+// single naming version for an Ingress frontend, so a caller that needs to
+// consider both a V1 and V2 candidate -- garbage collection, chiefly --
+// can check a resource against either set without first figuring out
+// which version created the Ingress.
+type FrontendNameSet struct {
+	LbName              string
+	TargetProxyHTTP     string
+	TargetProxyHTTPS    string
+	ForwardingRuleHTTP  string
+	ForwardingRuleHTTPS string
+	UrlMap              string
+}
+
+func frontendNameSet(namer IngressFrontendNamer) FrontendNameSet {
+	return FrontendNameSet{
+		LbName:              namer.LbName(),
+		TargetProxyHTTP:     namer.TargetProxy(HTTPProtocol),
+		TargetProxyHTTPS:    namer.TargetProxy(HTTPSProtocol),
+		ForwardingRuleHTTP:  namer.ForwardingRule(HTTPProtocol),
+		ForwardingRuleHTTPS: namer.ForwardingRule(HTTPSProtocol),
+		UrlMap:              namer.UrlMap(),
+	}
+}
+
+// MigrationCandidates returns the full set of V1 and V2 resource names for
+// ing, regardless of which version ing actually uses. Garbage collection
+// can check a GCE resource it's considering deleting against both sets to
+// recognize one ing owns no matter which naming scheme created it.
+func MigrationCandidates(ing *v1.Ingress, namer *Namer) (v1Names, v2Names FrontendNameSet) {
+	return frontendNameSet(newV1IngressFrontendNamer(ing, namer)), frontendNameSet(newV2IngressFrontendNamer(ing, namer))
+}
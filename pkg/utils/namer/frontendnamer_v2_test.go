@@ -0,0 +1,192 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestV2IngressFrontendNamer mirrors TestV1IngressFrontendNamer, covering
+// the same short-name and long-name cases, plus two long namespaces that
+// only differ in their last character -- the exact scenario truncate() (the
+// V1 scheme) gets wrong; TestV2IngressFrontendNamerNoCollision checks that
+// those two don't collide.
+func TestV2IngressFrontendNamer(t *testing.T) {
+	longString := "01234567890123456789012345678901234567890123456789"
+	hundredCharA := strings.Repeat("a", 100)
+	hundredCharB := hundredCharA[:len(hundredCharA)-1] + "b"
+	testCases := []struct {
+		desc      string
+		namespace string
+		name      string
+	}{
+		{"simple case", "namespace", "name"},
+		{"62 characters", longString[:23], longString[:24]},
+		{"63 characters", longString[:24], longString[:24]},
+		{"64 characters", longString[:24], longString[:25]},
+		{"long namespace", longString, "0"},
+		{"long name", "0", longString},
+		{"long name and namespace", longString, longString},
+		{"long namespace and name", hundredCharA, "svc"},
+		{"long namespace and name, differs only in last char", hundredCharB, "svc"},
+		{"both namespace and name long", strings.Repeat("0123456789", 10), strings.Repeat("9876543210", 10)},
+	}
+	for _, prefix := range []string{"k8s", "mci"} {
+		oldNamer := NewNamerWithPrefix(prefix, clusterUID, "")
+		for _, tc := range testCases {
+			t.Run(fmt.Sprintf("%s prefix %s", tc.desc, prefix), func(t *testing.T) {
+				ing := newIngress(tc.namespace, tc.name)
+				namer := newV2IngressFrontendNamer(ing, oldNamer)
+
+				wantLbName := v2LbName(tc.namespace, tc.name, clusterUID)
+				if diff := cmp.Diff(wantLbName, namer.LbName()); diff != "" {
+					t.Errorf("namer.LbName() mismatch (-want +got):\n%s", diff)
+				}
+				if len(namer.LbName()) > nameLenLimit {
+					t.Errorf("namer.LbName() = %q has length %d, want <= %d", namer.LbName(), len(namer.LbName()), nameLenLimit)
+				}
+
+				for _, tc2 := range []struct {
+					kind string
+					got  string
+				}{
+					{"tp", namer.TargetProxy(HTTPProtocol)},
+					{"tps", namer.TargetProxy(HTTPSProtocol)},
+					{"fw", namer.ForwardingRule(HTTPProtocol)},
+					{"fws", namer.ForwardingRule(HTTPSProtocol)},
+					{"um", namer.UrlMap()},
+				} {
+					want := v2ResourceName(prefix, tc2.kind, tc.namespace, tc.name, clusterUID)
+					if diff := cmp.Diff(want, tc2.got); diff != "" {
+						t.Errorf("resource %q mismatch (-want +got):\n%s", tc2.kind, diff)
+					}
+					if len(tc2.got) > nameLenLimit {
+						t.Errorf("resource %q = %q has length %d, want <= %d", tc2.kind, tc2.got, len(tc2.got), nameLenLimit)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestV2IngressFrontendNamerNoCollision proves the bug described in the V1
+// "64 characters"/"long namespace"/"long name" test cases is gone: two
+// 100-character namespaces differing only in their last character now
+// produce distinct names at every resource kind, instead of both
+// truncating to the same string.
+func TestV2IngressFrontendNamerNoCollision(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+	nsA := strings.Repeat("a", 100)
+	nsB := nsA[:len(nsA)-1] + "b"
+
+	namerA := newV2IngressFrontendNamer(newIngress(nsA, "svc"), oldNamer)
+	namerB := newV2IngressFrontendNamer(newIngress(nsB, "svc"), oldNamer)
+
+	if namerA.LbName() == namerB.LbName() {
+		t.Errorf("LbName collided for namespaces differing only in their last character: %q", namerA.LbName())
+	}
+	for _, protocol := range []NamerProtocol{HTTPProtocol, HTTPSProtocol} {
+		if namerA.TargetProxy(protocol) == namerB.TargetProxy(protocol) {
+			t.Errorf("TargetProxy(%v) collided: %q", protocol, namerA.TargetProxy(protocol))
+		}
+		if namerA.ForwardingRule(protocol) == namerB.ForwardingRule(protocol) {
+			t.Errorf("ForwardingRule(%v) collided: %q", protocol, namerA.ForwardingRule(protocol))
+		}
+	}
+	if namerA.UrlMap() == namerB.UrlMap() {
+		t.Errorf("UrlMap collided: %q", namerA.UrlMap())
+	}
+}
+
+func TestFrontendNamerVersion(t *testing.T) {
+	withAnnotation := newIngress("namespace", "name")
+	withAnnotation.Annotations = map[string]string{V1NamingAnnotationKey: "true"}
+	withoutAnnotation := newIngress("namespace", "name")
+
+	if got := FrontendNamerVersion(withAnnotation, V2); got != V1 {
+		t.Errorf("FrontendNamerVersion() with V1NamingAnnotationKey = %v, want %v", got, V1)
+	}
+	if got := FrontendNamerVersion(withoutAnnotation, V2); got != V2 {
+		t.Errorf("FrontendNamerVersion() without annotation, defaultVersion V2 = %v, want %v", got, V2)
+	}
+	if got := FrontendNamerVersion(withoutAnnotation, V1); got != V1 {
+		t.Errorf("FrontendNamerVersion() without annotation, defaultVersion V1 = %v, want %v", got, V1)
+	}
+}
+
+func TestNewIngressFrontendNamer(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+
+	legacyIng := newIngress("namespace", "name")
+	legacyIng.Annotations = map[string]string{V1NamingAnnotationKey: "true"}
+	if got, want := NewIngressFrontendNamer(legacyIng, V2, oldNamer).LbName(), oldNamer.LoadBalancer("namespace/name"); got != want {
+		t.Errorf("NewIngressFrontendNamer() for annotated Ingress returned LbName %q, want V1 name %q", got, want)
+	}
+
+	newIng := newIngress("namespace", "name")
+	if got, want := NewIngressFrontendNamer(newIng, V2, oldNamer).LbName(), v2LbName("namespace", "name", clusterUID); got != want {
+		t.Errorf("NewIngressFrontendNamer() for unannotated Ingress returned LbName %q, want V2 name %q", got, want)
+	}
+}
+
+func TestMigrationCandidates(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+	ing := newIngress("namespace", "name")
+
+	v1Names, v2Names := MigrationCandidates(ing, oldNamer)
+
+	wantV1 := oldNamer.LoadBalancer("namespace/name")
+	if v1Names.LbName != wantV1 {
+		t.Errorf("MigrationCandidates() v1Names.LbName = %q, want %q", v1Names.LbName, wantV1)
+	}
+	wantV2 := v2LbName("namespace", "name", clusterUID)
+	if v2Names.LbName != wantV2 {
+		t.Errorf("MigrationCandidates() v2Names.LbName = %q, want %q", v2Names.LbName, wantV2)
+	}
+	if v1Names.LbName == v2Names.LbName {
+		t.Errorf("MigrationCandidates() returned identical V1 and V2 names, want distinct candidates to check GC against")
+	}
+}
+
+// TestV2IngressFrontendNamerWithIngressClass parallels
+// TestV1IngressFrontendNamerWithIngressClass for the V2 naming scheme.
+func TestV2IngressFrontendNamerWithIngressClass(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+
+	gce := newV2IngressFrontendNamer(newIngressWithClass("namespace", "name", "gce"), oldNamer)
+	if got, want := gce.LbName(), "namespace-name-gce-1fd1a3a4--uid1"; got != want {
+		t.Errorf(`LbName() with IngressClassName "gce" = %q, want %q`, got, want)
+	}
+
+	gceInternal := newV2IngressFrontendNamer(newIngressWithClass("namespace", "name", "gce-internal"), oldNamer)
+	if got, want := gceInternal.LbName(), "namespace-name-gce-internal-2ee436e8--uid1"; got != want {
+		t.Errorf(`LbName() with IngressClassName "gce-internal" = %q, want %q`, got, want)
+	}
+
+	if gce.LbName() == gceInternal.LbName() {
+		t.Errorf("Ingresses with different IngressClassName produced the same LbName: %q", gce.LbName())
+	}
+
+	noClass := newV2IngressFrontendNamer(newIngress("namespace", "name"), oldNamer)
+	if got, want := noClass.LbName(), v2LbName("namespace", "name", clusterUID); got != want {
+		t.Errorf("LbName() with no IngressClass = %q, want byte-identical %q", got, want)
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import "fmt"
+
+// GatewayFrontendNamer extends IngressFrontendNamer with the extra naming
+// Gateway API's HTTPRoute needs. A route rule can fan out to several
+// backendRefs, each of which needs its own disjoint backend service name
+// that isn't tied to any single Gateway listener.
+type GatewayFrontendNamer interface {
+	IngressFrontendNamer
+
+	// RouteBackendName returns the name of the backend service for the
+	// ruleIdx'th rule / backendIdx'th backendRef of the HTTPRoute
+	// identified by (routeNamespace, routeName).
+	RouteBackendName(routeNamespace, routeName string, ruleIdx, backendIdx int) string
+}
+
+// v1GatewayFrontendNamer implements GatewayFrontendNamer for a single
+// Gateway listener, reusing the same Namer tail-truncation scheme as
+// v1IngressFrontendNamer. Every target proxy, forwarding rule, URL map and
+// route backend name it produces carries a "gw-" marker right after the
+// cluster prefix so a Gateway and an Ingress with the same namespace/name
+// on the same cluster never produce the same GCE resource name. LbName and
+// the SSL cert names carry no such marker; they differ from an Ingress's
+// only through the listener name folded into the load balancer key.
+type v1GatewayFrontendNamer struct {
+	lbName string
+	namer  *Namer
+}
+
+// newV1GatewayFrontendNamer returns a v1GatewayFrontendNamer for the
+// listener named listenerName on the Gateway (namespace, gatewayName).
+func newV1GatewayFrontendNamer(namespace, gatewayName, listenerName string, namer *Namer) GatewayFrontendNamer {
+	key := fmt.Sprintf("%s/%s/%s", namespace, gatewayName, listenerName)
+	return &v1GatewayFrontendNamer{
+		lbName: namer.LoadBalancer(key),
+		namer:  namer,
+	}
+}
+
+func (gn *v1GatewayFrontendNamer) LbName() string {
+	return gn.lbName
+}
+
+func (gn *v1GatewayFrontendNamer) TargetProxy(protocol NamerProtocol) string {
+	return gn.namer.resourceName("gw-"+targetProxyInfix(protocol), gn.lbName)
+}
+
+func (gn *v1GatewayFrontendNamer) SSLCertName(secretHash string) string {
+	return gn.namer.SSLCertName(gn.lbName, secretHash)
+}
+
+func (gn *v1GatewayFrontendNamer) SSLCertNameForSecret(secretNamespace, secretName, secretHash string) string {
+	return gn.namer.SSLCertNameForSecret(gn.lbName, secretNamespace, secretName, secretHash)
+}
+
+func (gn *v1GatewayFrontendNamer) IsSSLCertNameForLB(name string) (string, bool) {
+	hash, ok := gn.namer.sslCertLBHash(name)
+	if !ok || hash != sha256Hash(gn.lbName)[:lbHashLen] {
+		return "", false
+	}
+	return gn.lbName, true
+}
+
+func (gn *v1GatewayFrontendNamer) ForwardingRule(protocol NamerProtocol) string {
+	return gn.namer.resourceName("gw-"+forwardingRuleInfix(protocol), gn.lbName)
+}
+
+func (gn *v1GatewayFrontendNamer) UrlMap() string {
+	return gn.namer.resourceName("gw-um", gn.lbName)
+}
+
+// RouteBackendName returns the backend service name for a single
+// HTTPRoute backendRef. Unlike the frontend resources above, it is keyed
+// off the route's own namespace/name plus the rule and backend index
+// rather than this namer's lbName, since one Gateway listener can forward
+// to backends owned by many different HTTPRoutes.
+func (gn *v1GatewayFrontendNamer) RouteBackendName(routeNamespace, routeName string, ruleIdx, backendIdx int) string {
+	routeLBName := gn.namer.LoadBalancer(fmt.Sprintf("%s/%s", routeNamespace, routeName))
+	return gn.namer.resourceName(fmt.Sprintf("gw-be-%d-%d", ruleIdx, backendIdx), routeLBName)
+}
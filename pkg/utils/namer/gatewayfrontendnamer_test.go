@@ -0,0 +1,155 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// TestV1GatewayFrontendNamer mirrors TestV1IngressFrontendNamer: same
+// truncation behavior as the Ingress namer, but keyed off
+// (namespace, gatewayName, listenerName) and carrying a "-gw-" infix so
+// Gateway-owned resources never collide with Ingress-owned ones.
+func TestV1GatewayFrontendNamer(t *testing.T) {
+	longString := "01234567890123456789012345678901234567890123456789"
+	testCases := []struct {
+		desc         string
+		namespace    string
+		gatewayName  string
+		listenerName string
+		lbName       string
+		targetHTTP   string
+		targetHTTPS  string
+		fwRuleHTTP   string
+		fwRuleHTTPS  string
+		urlMap       string
+	}{
+		{
+			"simple case",
+			"namespace",
+			"gateway",
+			"https",
+			"namespace-gateway-https--uid1",
+			"%s-gw-tp-namespace-gateway-https--uid1",
+			"%s-gw-tps-namespace-gateway-https--uid1",
+			"%s-gw-fw-namespace-gateway-https--uid1",
+			"%s-gw-fws-namespace-gateway-https--uid1",
+			"%s-gw-um-namespace-gateway-https--uid1",
+		},
+		{
+			"long namespace and gateway name",
+			longString,
+			longString[:10],
+			"https",
+			"01234567890123456789012345678901234567890123456789-0123456789-0",
+			"%s-gw-tp-01234567890123456789012345678901234567890123456789-00",
+			"%s-gw-tps-01234567890123456789012345678901234567890123456789-0",
+			"%s-gw-fw-01234567890123456789012345678901234567890123456789-00",
+			"%s-gw-fws-01234567890123456789012345678901234567890123456789-0",
+			"%s-gw-um-01234567890123456789012345678901234567890123456789-00",
+		},
+	}
+	for _, prefix := range []string{"k8s", "mci"} {
+		oldNamer := NewNamerWithPrefix(prefix, clusterUID, "")
+		secretHash := fmt.Sprintf("%x", sha256.Sum256([]byte("test123")))[:16]
+		for _, tc := range testCases {
+			tc.desc = fmt.Sprintf("%s prefix %s", tc.desc, prefix)
+			t.Run(tc.desc, func(t *testing.T) {
+				namer := newV1GatewayFrontendNamer(tc.namespace, tc.gatewayName, tc.listenerName, oldNamer)
+
+				tc.targetHTTP = fmt.Sprintf(tc.targetHTTP, prefix)
+				tc.targetHTTPS = fmt.Sprintf(tc.targetHTTPS, prefix)
+				tc.fwRuleHTTP = fmt.Sprintf(tc.fwRuleHTTP, prefix)
+				tc.fwRuleHTTPS = fmt.Sprintf(tc.fwRuleHTTPS, prefix)
+				tc.urlMap = fmt.Sprintf(tc.urlMap, prefix)
+
+				if diff := cmp.Diff(tc.lbName, namer.LbName()); diff != "" {
+					t.Errorf("namer.LbName() mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.targetHTTP, namer.TargetProxy(HTTPProtocol)); diff != "" {
+					t.Errorf("namer.TargetProxy(HTTPProtocol) mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.targetHTTPS, namer.TargetProxy(HTTPSProtocol)); diff != "" {
+					t.Errorf("namer.TargetProxy(HTTPSProtocol) mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.fwRuleHTTP, namer.ForwardingRule(HTTPProtocol)); diff != "" {
+					t.Errorf("namer.ForwardingRule(HTTPProtocol) mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.fwRuleHTTPS, namer.ForwardingRule(HTTPSProtocol)); diff != "" {
+					t.Errorf("namer.ForwardingRule(HTTPSProtocol) mismatch (-want +got):\n%s", diff)
+				}
+				if diff := cmp.Diff(tc.urlMap, namer.UrlMap()); diff != "" {
+					t.Errorf("namer.UrlMap() mismatch (-want +got):\n%s", diff)
+				}
+				// SSLCertName just hashes LbName like the Ingress namer
+				// does, so it's collision-free for free; just check it
+				// matches that same formula.
+				lbHash := fmt.Sprintf("%x", sha256.Sum256([]byte(namer.LbName())))[:16]
+				wantSSLCertName := fmt.Sprintf("%s-ssl-%s-%s--uid1", prefix, lbHash, secretHash)
+				if diff := cmp.Diff(wantSSLCertName, namer.SSLCertName(secretHash)); diff != "" {
+					t.Errorf("namer.SSLCertName(%q) mismatch (-want +got):\n%s", secretHash, diff)
+				}
+			})
+		}
+	}
+}
+
+// TestV1GatewayFrontendNamerRouteBackendName exercises RouteBackendName,
+// the one part of GatewayFrontendNamer with no Ingress equivalent: a
+// single Gateway listener can serve backends owned by many different
+// HTTPRoutes, so the backend name is keyed off the route, not the
+// listener.
+func TestV1GatewayFrontendNamerRouteBackendName(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+	namer := newV1GatewayFrontendNamer("namespace", "gateway", "https", oldNamer)
+
+	got00 := namer.RouteBackendName("ns", "my-route", 0, 0)
+	want00 := "k8s-gw-be-0-0-ns-my-route--uid1"
+	if diff := cmp.Diff(want00, got00); diff != "" {
+		t.Errorf("RouteBackendName(rule 0, backend 0) mismatch (-want +got):\n%s", diff)
+	}
+
+	got11 := namer.RouteBackendName("ns", "my-route", 1, 1)
+	want11 := "k8s-gw-be-1-1-ns-my-route--uid1"
+	if diff := cmp.Diff(want11, got11); diff != "" {
+		t.Errorf("RouteBackendName(rule 1, backend 1) mismatch (-want +got):\n%s", diff)
+	}
+	if got00 == got11 {
+		t.Errorf("RouteBackendName should produce distinct names for distinct rule/backend indices, both got %q", got00)
+	}
+}
+
+// TestV1GatewayFrontendNamerNoCollisionWithIngress verifies that a Gateway
+// and an Ingress sharing the same cluster, namespace and name never
+// produce the same resource name, which is the entire reason for the
+// "-gw-" infix.
+func TestV1GatewayFrontendNamerNoCollisionWithIngress(t *testing.T) {
+	oldNamer := NewNamerWithPrefix("k8s", clusterUID, "")
+	ingressNamer := newV1IngressFrontendNamerFromLBName(oldNamer.LoadBalancer("team-a/site"), oldNamer)
+	gatewayNamer := newV1GatewayFrontendNamer("team-a", "site", "http", oldNamer)
+
+	if ingressNamer.LbName() == gatewayNamer.LbName() {
+		t.Errorf("ingress and gateway LbName collided: %q", ingressNamer.LbName())
+	}
+	if ingressNamer.TargetProxy(HTTPProtocol) == gatewayNamer.TargetProxy(HTTPProtocol) {
+		t.Errorf("ingress and gateway TargetProxy(HTTP) collided: %q", ingressNamer.TargetProxy(HTTPProtocol))
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+
+	"k8s.io/api/networking/v1"
+)
+
+// legacyIngressClassAnnotationKey is the pre-IngressClass way of selecting
+// an IngressClass, superseded by Spec.IngressClassName in
+// networking.k8s.io/v1. Still honored for one release so Ingresses
+// created before the migration keep routing to the same GCE resources.
+const legacyIngressClassAnnotationKey = "kubernetes.io/ingress.class"
+
+// ingressClass returns the effective IngressClass for ing: Spec.IngressClassName
+// if set, falling back to the legacy kubernetes.io/ingress.class annotation,
+// or "" if neither is set.
+func ingressClass(ing *v1.Ingress) string {
+	if ing.Spec.IngressClassName != nil && *ing.Spec.IngressClassName != "" {
+		return *ing.Spec.IngressClassName
+	}
+	return ing.Annotations[legacyIngressClassAnnotationKey]
+}
+
+// ingressNamingComponents returns the namespace and name used to key every
+// GCE resource name derived from ing. name folds in ing's IngressClass (if
+// any), so Ingresses that share a namespace/name but select different
+// classes get disjoint resource names. With no class set it's exactly
+// ing.Name, so Ingresses that pre-date IngressClassName see byte-identical
+// names to before.
+func ingressNamingComponents(ing *v1.Ingress) (namespace, name string) {
+	class := ingressClass(ing)
+	if class == "" {
+		return ing.Namespace, ing.Name
+	}
+	return ing.Namespace, fmt.Sprintf("%s-%s", ing.Name, class)
+}
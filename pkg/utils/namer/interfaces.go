@@ -0,0 +1,48 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+// IngressFrontendNamer derives the names of every GCE resource backing a
+// single Ingress frontend: the load balancer, per-protocol target proxies
+// and forwarding rules, the URL map, and SSL certificates.
+type IngressFrontendNamer interface {
+	// LbName returns the load balancer name used as the basis of every
+	// other frontend resource name.
+	LbName() string
+	// TargetProxy returns the name of the target proxy for protocol.
+	TargetProxy(protocol NamerProtocol) string
+	// SSLCertName returns the name of the SSL certificate derived from
+	// secretHash, a content hash of the certificate/key pair.
+	SSLCertName(secretHash string) string
+	// SSLCertNameForSecret returns the name of the SSL certificate for a
+	// single (secretNamespace, secretName, secretHash) reference, so an
+	// Ingress terminating many hostnames via SNI can have one
+	// certificate per secret.
+	SSLCertNameForSecret(secretNamespace, secretName, secretHash string) string
+	// IsSSLCertNameForLB reports whether name is an SSL cert name (from
+	// SSLCertName or SSLCertNameForSecret) belonging to this namer's
+	// Ingress. If so it returns this namer's own LbName() for
+	// convenience; a controller can feed every SSL cert in the project
+	// through this to discover which ones a given Ingress currently
+	// owns, so it can garbage collect the rest.
+	IsSSLCertNameForLB(name string) (lbName string, ok bool)
+	// ForwardingRule returns the name of the forwarding rule for
+	// protocol.
+	ForwardingRule(protocol NamerProtocol) string
+	// UrlMap returns the name of the URL map.
+	UrlMap() string
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// defaultPrefix is the resource name prefix used by the single
+	// cluster Ingress controller.
+	defaultPrefix = "k8s"
+	// clusterNameDelimiter separates the load balancer key from the
+	// cluster UID in every name this package generates.
+	clusterNameDelimiter = "--"
+	// lbHashLen is the length, in hex characters, of the lbName hash
+	// embedded in every SSL cert name. See SSLCertName.
+	lbHashLen = 16
+)
+
+// Namer is the cluster-wide namer every FrontendNamer is built on top of.
+// It knows how to derive the GCE resource names for a load balancer key
+// ("namespace/name") but has no notion of an individual Ingress or
+// Gateway; that's what the FrontendNamer implementations in this package
+// are for.
+type Namer struct {
+	prefix       string
+	uid          string
+	firewallName string
+}
+
+// NewNamer returns a new Namer with the default "k8s" prefix.
+func NewNamer(clusterUID, firewallName string) *Namer {
+	return NewNamerWithPrefix(defaultPrefix, clusterUID, firewallName)
+}
+
+// NewNamerWithPrefix returns a new Namer using prefix instead of the
+// default "k8s". The multi-cluster Ingress controller shares a GCP
+// project with the single-cluster controller and uses "mci" so their
+// resource names never collide.
+func NewNamerWithPrefix(prefix, clusterUID, firewallName string) *Namer {
+	return &Namer{
+		prefix:       prefix,
+		uid:          clusterUID,
+		firewallName: firewallName,
+	}
+}
+
+// UID returns the cluster UID used to disambiguate resource names between
+// clusters sharing a project.
+func (n *Namer) UID() string {
+	return n.uid
+}
+
+// Prefix returns the resource name prefix ("k8s" or "mci").
+func (n *Namer) Prefix() string {
+	return n.prefix
+}
+
+// FirewallName returns the firewall rule name for this cluster.
+func (n *Namer) FirewallName() string {
+	if n.firewallName != "" {
+		return n.firewallName
+	}
+	return n.uid
+}
+
+// LoadBalancer returns the load balancer name derived from key
+// ("namespace/name"). Every other V1 frontend resource name is derived by
+// decorating this name with a resource-kind infix.
+func (n *Namer) LoadBalancer(key string) string {
+	return truncate(fmt.Sprintf("%s%s%s", dashify(key), clusterNameDelimiter, n.uid))
+}
+
+// resourceName composes a GCE resource name out of this namer's prefix, an
+// infix identifying the resource kind, and lbName.
+func (n *Namer) resourceName(infix, lbName string) string {
+	return truncate(fmt.Sprintf("%s-%s-%s", n.prefix, infix, lbName))
+}
+
+// TargetProxy returns the name of the target proxy for lbName and
+// protocol.
+func (n *Namer) TargetProxy(lbName string, protocol NamerProtocol) string {
+	return n.resourceName(targetProxyInfix(protocol), lbName)
+}
+
+// ForwardingRule returns the name of the forwarding rule for lbName and
+// protocol.
+func (n *Namer) ForwardingRule(lbName string, protocol NamerProtocol) string {
+	return n.resourceName(forwardingRuleInfix(protocol), lbName)
+}
+
+// UrlMap returns the name of the URL map for lbName.
+func (n *Namer) UrlMap(lbName string) string {
+	return n.resourceName("um", lbName)
+}
+
+// SSLCertName returns the name of the SSL certificate for lbName and
+// secretHash, a content hash of the certificate/key pair. Unlike the
+// other resource names, this isn't built by decorating lbName directly:
+// lbName is hashed down to 16 hex characters first so the result always
+// fits the name limit regardless of how long lbName is.
+func (n *Namer) SSLCertName(lbName, secretHash string) string {
+	return fmt.Sprintf("%s-ssl-%s-%s%s%s", n.prefix, sha256Hash(lbName)[:lbHashLen], secretHash, clusterNameDelimiter, n.uid)
+}
+
+// SSLCertNameForSecret returns the SSL certificate name for a single
+// (secretNamespace, secretName, secretHash) reference, so an Ingress that
+// terminates many hostnames via SNI can have one certificate per secret
+// instead of needing to pick a single one. Besides the lbName hash
+// SSLCertName already embeds, the name also embeds an 8 hex character
+// hash of "secretNamespace/secretName", so two secrets on the same
+// Ingress never produce the same cert name. Rotating the secret's
+// contents changes secretHash (forcing a fresh GCE upload); renaming or
+// moving the secret changes the secret-ref portion instead, leaving the
+// rest of the name -- and therefore which LB it's recognized as
+// belonging to -- untouched.
+func (n *Namer) SSLCertNameForSecret(lbName, secretNamespace, secretName, secretHash string) string {
+	secretRefHash := sha256Hash(fmt.Sprintf("%s/%s", secretNamespace, secretName))[:8]
+	return fmt.Sprintf("%s-ssl-%s-%s-%s%s%s", n.prefix, sha256Hash(lbName)[:lbHashLen], secretRefHash, secretHash, clusterNameDelimiter, n.uid)
+}
+
+// sslCertLBHash extracts the lbName hash embedded in an SSL cert name
+// produced by SSLCertName or SSLCertNameForSecret, provided name carries
+// this namer's prefix and cluster UID. The hash is one-way, so it can
+// only be used to check equality against sha256Hash(lbName)[:lbHashLen]
+// for some known lbName, not to recover lbName itself.
+func (n *Namer) sslCertLBHash(name string) (string, bool) {
+	prefix := fmt.Sprintf("%s-ssl-", n.prefix)
+	suffix := fmt.Sprintf("%s%s", clusterNameDelimiter, n.uid)
+	if !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, suffix) {
+		return "", false
+	}
+	body := strings.TrimSuffix(strings.TrimPrefix(name, prefix), suffix)
+	if len(body) <= lbHashLen+1 || body[lbHashLen] != '-' {
+		return "", false
+	}
+	return body[:lbHashLen], true
+}
+
+func targetProxyInfix(protocol NamerProtocol) string {
+	if protocol == HTTPSProtocol {
+		return "tps"
+	}
+	return "tp"
+}
+
+func forwardingRuleInfix(protocol NamerProtocol) string {
+	if protocol == HTTPSProtocol {
+		return "fws"
+	}
+	return "fw"
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+// NamerProtocol identifies the protocol a target proxy or forwarding rule
+// name is being generated for. HTTP and HTTPS frontends for the same
+// load balancer share every other part of their name, so the protocol is
+// the only thing that needs to be threaded through separately.
+type NamerProtocol string
+
+const (
+	HTTPProtocol  NamerProtocol = "HTTP"
+	HTTPSProtocol NamerProtocol = "HTTPS"
+)
@@ -0,0 +1,136 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"fmt"
+	"testing"
+)
+
+// wantSSLCertNameForSecret composes the expected SSLCertNameForSecret
+// output the same way Namer.SSLCertNameForSecret does, so test cases don't
+// need to hardcode hash values.
+func wantSSLCertNameForSecret(prefix, uid, lbName, secretNamespace, secretName, secretHash string) string {
+	secretRefHash := sha256Hash(fmt.Sprintf("%s/%s", secretNamespace, secretName))[:8]
+	return fmt.Sprintf("%s-ssl-%s-%s-%s%s%s", prefix, sha256Hash(lbName)[:lbHashLen], secretRefHash, secretHash, clusterNameDelimiter, uid)
+}
+
+// TestSSLCertNameForSecret proves distinct secret refs on the same LB
+// produce distinct, deterministic names that still fit within the name
+// limit, and that changing the secret content hash alone changes the name.
+func TestSSLCertNameForSecret(t *testing.T) {
+	n := NewNamerWithPrefix("k8s", clusterUID, "")
+	lbName := n.LoadBalancer("namespace/name")
+
+	secretHashA := "cd27dce0000000000000000000000000000000000000000000000000000000"[:16]
+	secretHashB := "600c6f17000000000000000000000000000000000000000000000000000000"[:16]
+
+	nameA := n.SSLCertNameForSecret(lbName, "ns-a", "secret-a", secretHashA)
+	nameB := n.SSLCertNameForSecret(lbName, "ns-a", "secret-b", secretHashA)
+	nameC := n.SSLCertNameForSecret(lbName, "ns-b", "secret-a", secretHashA)
+
+	if want := wantSSLCertNameForSecret("k8s", clusterUID, lbName, "ns-a", "secret-a", secretHashA); nameA != want {
+		t.Errorf("SSLCertNameForSecret(ns-a, secret-a) = %q, want %q", nameA, want)
+	}
+	if nameA == nameB {
+		t.Errorf("SSLCertNameForSecret produced identical names for secret-a and secret-b: %q", nameA)
+	}
+	if nameA == nameC {
+		t.Errorf("SSLCertNameForSecret produced identical names for ns-a and ns-b: %q", nameA)
+	}
+	if nameB == nameC {
+		t.Errorf("SSLCertNameForSecret produced identical names for (ns-a,secret-b) and (ns-b,secret-a): %q", nameB)
+	}
+	for _, got := range []string{nameA, nameB, nameC} {
+		if len(got) > 63 {
+			t.Errorf("SSLCertNameForSecret() = %q has length %d, want <= 63", got, len(got))
+		}
+	}
+
+	rotated := n.SSLCertNameForSecret(lbName, "ns-a", "secret-a", secretHashB)
+	if rotated == nameA {
+		t.Errorf("SSLCertNameForSecret did not change when secretHash changed: %q", rotated)
+	}
+}
+
+// TestIsSSLCertNameForLB proves IsSSLCertNameForLB recognizes every name
+// SSLCertName and SSLCertNameForSecret produce for a given LB, and rejects
+// names belonging to a different LB, prefix, or cluster.
+func TestIsSSLCertNameForLB(t *testing.T) {
+	n := NewNamerWithPrefix("k8s", clusterUID, "")
+	lbName := n.LoadBalancer("namespace/name")
+	otherLBName := n.LoadBalancer("namespace/other")
+
+	certName := n.SSLCertName(lbName, "abcd1234")
+	secretCertName := n.SSLCertNameForSecret(lbName, "ns-a", "secret-a", "abcd1234")
+
+	ing := newV1IngressFrontendNamerFromLBName(lbName, n)
+
+	for _, tc := range []struct {
+		desc string
+		name string
+	}{
+		{"SSLCertName", certName},
+		{"SSLCertNameForSecret", secretCertName},
+	} {
+		gotLBName, ok := ing.IsSSLCertNameForLB(tc.name)
+		if !ok {
+			t.Errorf("%s: IsSSLCertNameForLB(%q) = (_, false), want true", tc.desc, tc.name)
+			continue
+		}
+		if gotLBName != lbName {
+			t.Errorf("%s: IsSSLCertNameForLB(%q) = (%q, true), want (%q, true)", tc.desc, tc.name, gotLBName, lbName)
+		}
+	}
+
+	otherIng := newV1IngressFrontendNamerFromLBName(otherLBName, n)
+	if _, ok := otherIng.IsSSLCertNameForLB(certName); ok {
+		t.Errorf("IsSSLCertNameForLB() for an unrelated LB's namer = true, want false")
+	}
+
+	for _, tc := range []struct {
+		desc string
+		name string
+	}{
+		{"wrong prefix", "mci-ssl-" + certName[len("k8s-ssl-"):]},
+		{"wrong cluster UID", certName + "2"},
+		{"not a cert name at all", "k8s-tp-namespace-name--uid1"},
+	} {
+		if _, ok := ing.IsSSLCertNameForLB(tc.name); ok {
+			t.Errorf("%s: IsSSLCertNameForLB(%q) = true, want false", tc.desc, tc.name)
+		}
+	}
+}
+
+// TestGatewaySSLCertNameForSecret proves the Gateway frontend namer wires
+// SSLCertNameForSecret/IsSSLCertNameForLB through to the same Namer logic
+// as the Ingress frontend namer.
+func TestGatewaySSLCertNameForSecret(t *testing.T) {
+	n := NewNamerWithPrefix("k8s", clusterUID, "")
+	gwNamer := newV1GatewayFrontendNamer("namespace", "gateway", "https", n)
+
+	got := gwNamer.SSLCertNameForSecret("ns-a", "secret-a", "abcd1234")
+	want := n.SSLCertNameForSecret(gwNamer.LbName(), "ns-a", "secret-a", "abcd1234")
+	if got != want {
+		t.Errorf("GatewayFrontendNamer.SSLCertNameForSecret() = %q, want %q", got, want)
+	}
+
+	lbName, ok := gwNamer.IsSSLCertNameForLB(got)
+	if !ok || lbName != gwNamer.LbName() {
+		t.Errorf("GatewayFrontendNamer.IsSSLCertNameForLB(%q) = (%q, %v), want (%q, true)", got, lbName, ok, gwNamer.LbName())
+	}
+}
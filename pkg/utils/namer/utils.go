@@ -0,0 +1,102 @@
+/*
+Copyright 2019 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package namer
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// nameLenLimit is the longest name the V1 naming scheme will produce
+// without truncating, one short of the real 63 character GCE resource name
+// limit so that truncated names still have room for the single digit
+// `truncate` appends.
+const nameLenLimit = 62
+
+// truncate fits name within nameLenLimit. If name is already short enough
+// it is returned unchanged. Otherwise it is cut down to nameLenLimit
+// characters and a single digit is appended to flag that truncation
+// happened.
+//
+// This does not guarantee uniqueness: two long keys that only differ after
+// the cutoff point truncate to the exact same name, which is the
+// collision FrontendNamerVersion V2 (frontendnamer_v2.go) exists to fix by
+// folding a hash of the full key into the name instead of just chopping
+// the tail off. V1 keeps this behavior so already-created Ingresses don't
+// change their GCE resource names out from under them.
+func truncate(name string) string {
+	if len(name) <= nameLenLimit {
+		return name
+	}
+	return name[:nameLenLimit] + "0"
+}
+
+// dashify replaces every "/" in key with "-" so it can be embedded in a
+// GCE resource name, which may not contain slashes.
+func dashify(key string) string {
+	return strings.ReplaceAll(key, "/", "-")
+}
+
+// sha256Hash returns the hex-encoded sha256 digest of s.
+func sha256Hash(s string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(s)))
+}
+
+// trimFieldsEvenly trims the given fields so their combined length fits
+// within maxLength, distributing the budget evenly across fields instead
+// of truncating them uniformly from one end. Fields shorter than their
+// even share don't get trimmed at all; the budget they don't use is
+// rolled forward to the remaining fields. Used by the V2 naming scheme to
+// keep both the namespace and name readable in a truncated name instead
+// of one swallowing the other.
+func trimFieldsEvenly(maxLength int, fields ...string) []string {
+	if maxLength <= 0 {
+		return fields
+	}
+	total := 0
+	for _, f := range fields {
+		total += len(f)
+	}
+	if total <= maxLength {
+		return fields
+	}
+
+	// Trim the shortest fields first so any budget they don't need rolls
+	// forward to the longer fields still waiting their turn.
+	order := make([]int, len(fields))
+	for i := range fields {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return len(fields[order[i]]) < len(fields[order[j]]) })
+
+	trimmed := make([]string, len(fields))
+	remaining := maxLength
+	remainingFields := len(fields)
+	for _, idx := range order {
+		share := remaining / remainingFields
+		f := fields[idx]
+		if len(f) > share {
+			f = f[:share]
+		}
+		trimmed[idx] = f
+		remaining -= len(f)
+		remainingFields--
+	}
+	return trimmed
+}